@@ -0,0 +1,180 @@
+package ebay
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SearchIterator walks the pages of a Browse item_summary search, following
+// eBay's pagination so callers don't have to hand-thread offset/limit.
+//
+// eBay API docs: https://developer.ebay.com/api-docs/buy/browse/resources/item_summary/methods/search
+type SearchIterator struct {
+	browse *BrowseService
+	ctx    context.Context
+	opts   []Opt
+
+	reqTemplate *http.Request
+	nextURL     string
+	started     bool
+
+	page  *SearchResponse
+	index int
+	seen  int
+
+	done bool
+	err  error
+}
+
+// SearchIter returns an iterator over every item matching opts, transparently
+// following pagination until total is exhausted or ctx is cancelled.
+func (s *BrowseService) SearchIter(ctx context.Context, opts ...Opt) *SearchIterator {
+	return &SearchIterator{browse: s, ctx: ctx, opts: opts}
+}
+
+// Next advances the iterator to the next item, fetching further pages as
+// needed. It returns false once results are exhausted or ctx is done; call
+// Err afterwards to tell exhaustion from failure.
+func (it *SearchIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	for it.page == nil || it.index >= len(it.page.ItemSummaries) {
+		if it.started && (it.nextURL == "" || (it.page.Total > 0 && it.seen >= it.page.Total)) {
+			it.done = true
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+		it.started = true
+		it.index = 0
+	}
+
+	it.index++
+	it.seen++
+	return true
+}
+
+// Item returns the item the most recent call to Next advanced to.
+func (it *SearchIterator) Item() *ItemSummary {
+	if it.page == nil || it.index == 0 || it.index > len(it.page.ItemSummaries) {
+		return nil
+	}
+	return &it.page.ItemSummaries[it.index-1]
+}
+
+// Page returns the response backing the item Next last returned.
+func (it *SearchIterator) Page() *SearchResponse {
+	return it.page
+}
+
+// Err returns the error, if any, that stopped iteration early.
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+func (it *SearchIterator) fetchPage() error {
+	req, err := it.nextRequest()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := it.browse.client.withDeadline(it.ctx)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	resp, err := it.browse.client.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(req, resp, "", ""); err != nil {
+		return err
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var page SearchResponse
+	if err := json.Unmarshal(raw, &page); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// eBay's "next" page is usually a JSON field, but the iterator also
+	// understands an RFC-5988 Link header so the same machinery generalizes
+	// to other paginated endpoints (Feed, Offer, Order) that use one.
+	var body struct {
+		Next string `json:"next,omitempty"`
+	}
+	_ = json.Unmarshal(raw, &body)
+
+	it.reqTemplate = req
+	it.page = &page
+	it.nextURL = nextPageURL(resp.Header, body.Next)
+	return nil
+}
+
+func (it *SearchIterator) nextRequest() (*http.Request, error) {
+	if it.reqTemplate == nil {
+		return it.browse.client.NewRequest("GET", "buy/browse/v1/item_summary/search", nil, it.opts...)
+	}
+	u, err := it.reqTemplate.URL.Parse(it.nextURL)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req := it.reqTemplate.Clone(it.ctx)
+	req.URL = u
+	return req, nil
+}
+
+// nextPageURL extracts the next-page URL from an RFC-5988 Link header
+// (rel="next"), falling back to the JSON-provided next when no such header
+// is present.
+func nextPageURL(header http.Header, fallback string) string {
+	for _, line := range header.Values("Link") {
+		for _, link := range strings.Split(line, ",") {
+			segments := strings.Split(link, ";")
+			if len(segments) < 2 {
+				continue
+			}
+			url := strings.Trim(strings.TrimSpace(segments[0]), "<>")
+			for _, param := range segments[1:] {
+				if strings.TrimSpace(param) == `rel="next"` {
+					return url
+				}
+			}
+		}
+	}
+	return fallback
+}
+
+// Collect drains iter into a slice, stopping after max items (or when
+// exhausted, if max <= 0).
+func Collect(ctx context.Context, iter *SearchIterator, max int) ([]ItemSummary, error) {
+	var items []ItemSummary
+	for ctx.Err() == nil && iter.Next() {
+		items = append(items, *iter.Item())
+		if max > 0 && len(items) >= max {
+			break
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return items, err
+	}
+	return items, errors.WithStack(ctx.Err())
+}
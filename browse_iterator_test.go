@@ -0,0 +1,100 @@
+package ebay_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kemics/ebay"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchIterFollowsPagesAndStopsOnLastPage(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/buy/browse/v1/item_summary/search", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			assert.Equal(t, "", r.URL.Query().Get("offset"))
+			fmt.Fprint(w, `{"total":3,"itemSummaries":[{"itemId":"1"},{"itemId":"2"}],"next":"`+r.URL.Path+`?offset=2"}`)
+		case 2:
+			assert.Equal(t, "2", r.URL.Query().Get("offset"))
+			fmt.Fprint(w, `{"total":3,"itemSummaries":[{"itemId":"3"}]}`)
+		default:
+			t.Fatalf("expected only 2 page fetches, got a 3rd")
+		}
+	})
+
+	iter := client.Buy.Browse.SearchIter(context.Background(), ebay.OptBrowseSearch("search"))
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Item().ItemID)
+	}
+	assert.Nil(t, iter.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Equal(t, 2, calls)
+}
+
+func TestSearchIterSurfacesMidStreamError(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/buy/browse/v1/item_summary/search", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			fmt.Fprint(w, `{"total":2,"itemSummaries":[{"itemId":"1"}],"next":"`+r.URL.Path+`?offset=1"}`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"errors":[{"errorId":12345,"message":"boom"}]}`)
+	})
+
+	iter := client.Buy.Browse.SearchIter(context.Background(), ebay.OptBrowseSearch("search"))
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Item().ItemID)
+	}
+	assert.Equal(t, []string{"1"}, ids)
+	assert.NotNil(t, iter.Err())
+	assert.True(t, ebay.IsError(iter.Err(), 12345))
+}
+
+func TestCollectStopsAtMax(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/buy/browse/v1/item_summary/search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total":5,"itemSummaries":[{"itemId":"1"},{"itemId":"2"},{"itemId":"3"}]}`)
+	})
+
+	iter := client.Buy.Browse.SearchIter(context.Background(), ebay.OptBrowseSearch("search"))
+	items, err := ebay.Collect(context.Background(), iter, 2)
+	assert.Nil(t, err)
+	assert.Len(t, items, 2)
+}
+
+func TestSearchIterHonorsRequestDeadline(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := ebay.NewCustomClient(server.Client(), server.URL+"/", ebay.WithRequestDeadline(10*time.Millisecond))
+	assert.Nil(t, err)
+
+	mux.HandleFunc("/buy/browse/v1/item_summary/search", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, `{"total":1,"itemSummaries":[{"itemId":"1"}]}`)
+	})
+
+	iter := client.Buy.Browse.SearchIter(context.Background(), ebay.OptBrowseSearch("search"))
+	assert.False(t, iter.Next())
+	assert.True(t, errors.Is(iter.Err(), context.DeadlineExceeded))
+}
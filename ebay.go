@@ -7,9 +7,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -31,6 +31,7 @@ const (
 // eBay API docs: https://developer.ebay.com/api-docs/buy/static/buy-landing.html
 type BuyAPI struct {
 	Browse *BrowseService
+	Feed   *FeedService
 }
 
 // Client manages communication with the eBay API.
@@ -39,39 +40,54 @@ type Client struct {
 	baseURL *url.URL     // Base URL for API requests.
 
 	// eBay APIs.
-	Buy BuyAPI
+	Buy  BuyAPI
+	Sell SellAPI
+
+	limiter     *rateLimiter
+	retryPolicy *RetryPolicy
+
+	logger          func(RequestEvent)
+	requestDeadline time.Duration
+	bodyCaptureMax  int
 }
 
 // NewClient returns a new eBay API client.
 // If a nil httpClient is provided, http.DefaultClient will be used.
-func NewClient(httpclient *http.Client) *Client {
-	return newClient(httpclient, BaseURL)
+func NewClient(httpclient *http.Client, opts ...ClientOption) *Client {
+	return newClient(httpclient, BaseURL, opts...)
 }
 
 // NewSandboxClient returns a new eBay sandbox API client.
 // If a nil httpClient is provided, http.DefaultClient will be used.
-func NewSandboxClient(httpclient *http.Client) *Client {
-	return newClient(httpclient, SandboxBaseURL)
+func NewSandboxClient(httpclient *http.Client, opts ...ClientOption) *Client {
+	return newClient(httpclient, SandboxBaseURL, opts...)
 }
 
 // NewCustomClient returns a new custom eBay API client.
 // BaseURL should have a trailing slash.
 // If a nil httpClient is provided, http.DefaultClient will be used.
-func NewCustomClient(httpclient *http.Client, baseURL string) (*Client, error) {
+func NewCustomClient(httpclient *http.Client, baseURL string, opts ...ClientOption) (*Client, error) {
 	if !strings.HasSuffix(baseURL, "/") {
 		return nil, fmt.Errorf("BaseURL %s must have a trailing slash", baseURL)
 	}
-	return newClient(httpclient, baseURL), nil
+	return newClient(httpclient, baseURL, opts...), nil
 }
 
-func newClient(httpclient *http.Client, baseURL string) *Client {
+func newClient(httpclient *http.Client, baseURL string, opts ...ClientOption) *Client {
 	if httpclient == nil {
 		httpclient = http.DefaultClient
 	}
 	url, _ := url.Parse(baseURL)
 	c := &Client{client: httpclient, baseURL: url}
+	for _, opt := range opts {
+		opt(c)
+	}
 	c.Buy = BuyAPI{
 		Browse: (*BrowseService)(&service{c}),
+		Feed:   (*FeedService)(&service{c}),
+	}
+	c.Sell = SellAPI{
+		Trading: (*TradingService)(&service{c}),
 	}
 	return c
 }
@@ -115,13 +131,21 @@ func (c *Client) NewRequest(method, url string, body interface{}, opts ...Opt) (
 
 // Do sends an API request and stores the JSON decoded value into v.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) error {
-	dump, _ := httputil.DumpRequest(req, true)
-	resp, err := c.client.Do(req.WithContext(ctx))
+	ctx, cancel := c.withDeadline(ctx)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	reqDump := c.dumpRequest(req)
+	resp, err := c.send(ctx, req)
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
-	defer resp.Body.Close()
-	if err := CheckResponse(req, resp, string(dump)); err != nil {
+	defer func() {
+		io.Copy(io.Discard, resp.Body) //nolint:errcheck
+		resp.Body.Close()
+	}()
+	if err := CheckResponse(req, resp, reqDump, c.dumpResponse(resp)); err != nil {
 		return err
 	}
 	if v == nil {
@@ -130,6 +154,81 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) error
 	return errors.WithStack(json.NewDecoder(resp.Body).Decode(v))
 }
 
+// DoStream sends an API request like Do, but hands the raw, undecoded
+// response body back to the caller instead of JSON-decoding it. This is
+// used for endpoints that stream large or non-JSON payloads, such as
+// FeedService. CheckResponse still runs on non-2xx responses. The caller is
+// responsible for draining and closing the returned body.
+func (c *Client) DoStream(ctx context.Context, req *http.Request) (io.ReadCloser, error) {
+	ctx, cancel := c.withDeadline(ctx)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckResponse(req, resp, "", c.dumpResponse(resp)); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// send issues req, applying the client's rate limiter and retry policy, if
+// any were configured with WithRateLimit/WithRetry, and reports a
+// RequestEvent per attempt to a logger configured with WithLogger.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	family := familyForPath(req.URL.Path)
+	maxAttempts := 1
+	if c.retryPolicy != nil {
+		maxAttempts = c.retryPolicy.MaxAttempts
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if err := rewindBody(req); err != nil {
+				return nil, err
+			}
+		}
+		if c.limiter != nil {
+			if err := c.limiter.wait(ctx, family); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+
+		start := time.Now()
+		resp, err := c.client.Do(req.WithContext(ctx))
+		c.logRequest(req, resp, attempt, time.Since(start), err)
+		if err != nil {
+			if c.retryPolicy == nil || attempt == maxAttempts-1 {
+				return nil, errors.WithStack(err)
+			}
+		} else if c.retryPolicy == nil || attempt == maxAttempts-1 || !c.retryPolicy.shouldRetry(resp) {
+			return resp, nil
+		} else {
+			delay := parseRetryAfter(resp.Header.Get("Retry-After"))
+			io.Copy(io.Discard, resp.Body) //nolint:errcheck
+			resp.Body.Close()
+			if delay <= 0 {
+				delay = c.retryPolicy.backoff(attempt)
+			}
+			if c.limiter != nil {
+				c.limiter.recordRetry(family)
+			}
+			if err := sleep(ctx, delay); err != nil {
+				return nil, errors.WithStack(err)
+			}
+			continue
+		}
+
+		if err := sleep(ctx, c.retryPolicy.backoff(attempt)); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+}
+
 // Error describes one error caused by an eBay API request.
 //
 // eBay API docs: https://developer.ebay.com/api-docs/static/handling-error-messages.html
@@ -154,20 +253,24 @@ type Error struct {
 type ErrorData struct {
 	Errors []Error `json:"errors,omitempty"`
 
-	response    *http.Response
-	requestDump string
+	response     *http.Response
+	requestDump  string
+	responseDump string
 }
 
 func (e *ErrorData) Error() string {
-	return fmt.Sprintf("%d\n%s\n%+v", e.response.StatusCode, e.requestDump, e.Errors)
+	return fmt.Sprintf("%d\n%s\n%s\n%+v", e.response.StatusCode, e.requestDump, e.responseDump, e.Errors)
 }
 
-// CheckResponse checks the API response for errors, and returns them if present.
-func CheckResponse(req *http.Request, resp *http.Response, dump string) error {
+// CheckResponse checks the API response for errors, and returns them if
+// present. requestDump and responseDump are attached to the returned
+// ErrorData for debugging; pass "" for either when no logger is configured,
+// since capturing them costs a dump for no reader.
+func CheckResponse(req *http.Request, resp *http.Response, requestDump, responseDump string) error {
 	if s := resp.StatusCode; 200 <= s && s < 300 {
 		return nil
 	}
-	errorData := &ErrorData{response: resp, requestDump: dump}
+	errorData := &ErrorData{response: resp, requestDump: requestDump, responseDump: responseDump}
 	_ = json.NewDecoder(resp.Body).Decode(errorData)
 	return errorData
 }
@@ -0,0 +1,344 @@
+package ebay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// eBay allows fetching feed files in chunks of up to 100 MB via the Range
+// header; files larger than that must be downloaded across several requests.
+const feedChunkSize = 100 * 1024 * 1024
+
+// FeedService handles communication with eBay's Buy Feed API, which serves
+// bulk, gzip-compressed TSV files rather than JSON responses.
+//
+// eBay API docs: https://developer.ebay.com/api-docs/buy/feed/overview.html
+type FeedService service
+
+// FeedItem is a single row of an eBay item feed file.
+type FeedItem struct {
+	ItemID      string
+	Title       string
+	Price       Money
+	Quantity    int
+	ConditionID string
+
+	// Fields holds every column of the row keyed by its header name, so
+	// callers can reach columns FeedItem doesn't promote to a field.
+	Fields map[string]string
+}
+
+// WithRange restricts a feed download to the given inclusive byte range,
+// mirroring eBay's chunked-download requirement for files over 100 MB.
+func WithRange(start, end int64) Opt {
+	return func(r *http.Request) {
+		r.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	}
+}
+
+// GetItemFeed downloads the item feed for categoryID on date, transparently
+// decompressing the gzip stream and following eBay's Range-header chunking
+// for files larger than 100 MB. The returned FeedIterator must be closed
+// once the caller is done reading.
+//
+// eBay API docs: https://developer.ebay.com/api-docs/buy/feed/resources/item/methods/getItemFeed
+func (s *FeedService) GetItemFeed(ctx context.Context, categoryID string, date time.Time, opts ...Opt) (*FeedIterator, error) {
+	return s.newFeedIterator(ctx, "buy/feed/v1_beta/item", categoryID, date, opts...)
+}
+
+// GetItemSnapshotFeed downloads the item_snapshot feed for categoryID on date.
+//
+// eBay API docs: https://developer.ebay.com/api-docs/buy/feed/resources/item_snapshot/methods/getItemSnapshotFeed
+func (s *FeedService) GetItemSnapshotFeed(ctx context.Context, categoryID string, date time.Time, opts ...Opt) (*FeedIterator, error) {
+	return s.newFeedIterator(ctx, "buy/feed/v1_beta/item_snapshot", categoryID, date, opts...)
+}
+
+// GetItemGroupFeed downloads the item_group feed for categoryID on date.
+//
+// eBay API docs: https://developer.ebay.com/api-docs/buy/feed/resources/item_group/methods/getItemGroupFeed
+func (s *FeedService) GetItemGroupFeed(ctx context.Context, categoryID string, date time.Time, opts ...Opt) (*FeedIterator, error) {
+	return s.newFeedIterator(ctx, "buy/feed/v1_beta/item_group", categoryID, date, opts...)
+}
+
+// ForEachFeedItem downloads a feed the same way GetItemFeed does and invokes
+// fn for every row, hiding the chunking and iteration machinery from the
+// caller. Iteration stops at the first error returned by fn.
+func (s *FeedService) ForEachFeedItem(ctx context.Context, categoryID string, date time.Time, fn func(*FeedItem) error, opts ...Opt) error {
+	it, err := s.GetItemFeed(ctx, categoryID, date, opts...)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for {
+		item, err := it.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+}
+
+// FeedIterator streams the rows of a decompressed feed file one at a time,
+// so callers can process multi-GB files without buffering them in memory.
+type FeedIterator struct {
+	client *Client
+	url    string
+	ctx    context.Context
+	opts   []Opt
+
+	scanner *bufio.Scanner
+	gz      *gzip.Reader
+	body    io.ReadCloser
+	header  []string
+
+	rangeStart int64
+	rangeEnd   int64
+	// lastChunk records whether the most recently fetched range completed
+	// the file, per isLastChunk, so Next doesn't have to spend a wasted
+	// round trip discovering that via a follow-up 416.
+	lastChunk bool
+	done      bool
+}
+
+func (s *FeedService) newFeedIterator(ctx context.Context, path, categoryID string, date time.Time, opts ...Opt) (*FeedIterator, error) {
+	req, err := s.client.NewRequest("GET", path, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("category_id", categoryID)
+	q.Set("feed_scope", "NEWLY_LISTED")
+	q.Set("date", date.Format("20060102"))
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	if req.Header.Get("Range") == "" {
+		req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", feedChunkSize-1))
+	}
+
+	it := &FeedIterator{client: s.client, url: req.URL.String(), ctx: ctx, opts: opts}
+	it.rangeStart, it.rangeEnd = parseRangeHeader(req.Header.Get("Range"))
+
+	body, lastChunk, err := s.client.fetchChunk(ctx, req, it.rangeEnd-it.rangeStart+1)
+	if err != nil {
+		return nil, err
+	}
+	it.lastChunk = lastChunk
+	if err := it.reset(body); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// fetchChunk issues req directly through send, the same way
+// SearchIterator.fetchPage bypasses Do/DoStream, so the response headers are
+// still around to tell whether this was the feed's last chunk.
+func (c *Client) fetchChunk(ctx context.Context, req *http.Request, requestedSize int64) (body io.ReadCloser, lastChunk bool, err error) {
+	ctx, cancel := c.withDeadline(ctx)
+	if cancel != nil {
+		defer cancel()
+	}
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := CheckResponse(req, resp, "", ""); err != nil {
+		resp.Body.Close()
+		return nil, false, err
+	}
+	return resp.Body, isLastChunk(resp, requestedSize), nil
+}
+
+// isLastChunk reports whether resp completed a feed file: either its
+// Content-Range names a total we've now reached, or (for servers that answer
+// a Range request with a plain 200 once the file is shorter than the
+// requested chunk) its body is smaller than requestedSize. Either signal
+// lets FeedIterator stop without spending a further request to find out via
+// a 416.
+func isLastChunk(resp *http.Response, requestedSize int64) bool {
+	if _, end, total, ok := parseContentRange(resp.Header.Get("Content-Range")); ok {
+		return total >= 0 && end+1 >= total
+	}
+	return resp.ContentLength >= 0 && resp.ContentLength < requestedSize
+}
+
+// parseContentRange parses a "bytes start-end/total" Content-Range header
+// value, reporting total as -1 when the server sent "*" for an unknown size.
+func parseContentRange(h string) (start, end, total int64, ok bool) {
+	h = strings.TrimPrefix(h, "bytes ")
+	parts := strings.SplitN(h, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, 0, false
+	}
+	bounds := strings.SplitN(parts[0], "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, 0, false
+	}
+	start, err1 := strconv.ParseInt(bounds[0], 10, 64)
+	end, err2 := strconv.ParseInt(bounds[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return 0, 0, 0, false
+	}
+	if parts[1] == "*" {
+		return start, end, -1, true
+	}
+	total, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return start, end, total, true
+}
+
+func (it *FeedIterator) reset(body io.ReadCloser) error {
+	if it.gz != nil {
+		it.gz.Close()
+	}
+	if it.body != nil {
+		it.body.Close()
+	}
+	gz, err := gzip.NewReader(body)
+	if err != nil {
+		body.Close()
+		return errors.WithStack(err)
+	}
+	it.gz = gz
+	it.body = body
+	it.scanner = bufio.NewScanner(gz)
+	it.scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	if it.header == nil && it.scanner.Scan() {
+		it.header = strings.Split(it.scanner.Text(), "\t")
+	}
+	return nil
+}
+
+// Next returns the next row of the feed, or io.EOF once every row (and
+// every Range chunk) has been consumed. An error other than io.EOF means the
+// feed was cut short by a failure, not that it genuinely ended.
+func (it *FeedIterator) Next() (*FeedItem, error) {
+	if it.done {
+		return nil, io.EOF
+	}
+	for {
+		if it.scanner.Scan() {
+			return it.parseRow(it.scanner.Text()), nil
+		}
+		if err := it.scanner.Err(); err != nil {
+			it.done = true
+			return nil, errors.WithStack(err)
+		}
+		if it.lastChunk {
+			it.done = true
+			return nil, io.EOF
+		}
+		more, err := it.fetchNextChunk()
+		if err != nil {
+			it.done = true
+			return nil, err
+		}
+		if !more {
+			it.done = true
+			return nil, io.EOF
+		}
+	}
+}
+
+// feedChunkRetries bounds how many attempts fetchNextChunk makes at a single
+// Range request before giving up and returning the last failure.
+const feedChunkRetries = 3
+
+// fetchNextChunk issues the next ranged request once the current gzip chunk
+// is exhausted, retrying on transient failures the way eBay's chunked feed
+// downloads require for files over 100 MB. It reports whether a further
+// chunk was available; err is non-nil only when retries were exhausted on a
+// genuine failure, not when the feed simply ended (a 416 reports that as
+// more == false, err == nil).
+func (it *FeedIterator) fetchNextChunk() (more bool, err error) {
+	nextStart := it.rangeEnd + 1
+	nextEnd := nextStart + feedChunkSize - 1
+
+	opts := append(append([]Opt{}, it.opts...), WithRange(nextStart, nextEnd))
+
+	var lastErr error
+	for attempt := 0; attempt < feedChunkRetries; attempt++ {
+		req, err := it.client.NewRequest("GET", it.url, nil, opts...)
+		if err != nil {
+			return false, err
+		}
+
+		body, lastChunk, err := it.client.fetchChunk(it.ctx, req, nextEnd-nextStart+1)
+		if err != nil {
+			// A 416 (Range Not Satisfiable) means we've read past the end
+			// of the file: the feed is simply exhausted, not an error.
+			if errData, ok := err.(*ErrorData); ok && errData.response.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+				return false, nil
+			}
+			lastErr = err
+			continue
+		}
+		if err := it.reset(body); err != nil {
+			lastErr = err
+			continue
+		}
+		it.rangeStart, it.rangeEnd = nextStart, nextEnd
+		it.lastChunk = lastChunk
+		return true, nil
+	}
+	return false, lastErr
+}
+
+func (it *FeedIterator) parseRow(line string) *FeedItem {
+	cols := strings.Split(line, "\t")
+	fields := make(map[string]string, len(cols))
+	for i, name := range it.header {
+		if i < len(cols) {
+			fields[name] = cols[i]
+		}
+	}
+	qty, _ := strconv.Atoi(fields["availableQuantity"])
+	return &FeedItem{
+		ItemID:      fields["itemId"],
+		Title:       fields["title"],
+		Price:       Money{Value: fields["price"], Currency: fields["currency"]},
+		Quantity:    qty,
+		ConditionID: fields["conditionId"],
+		Fields:      fields,
+	}
+}
+
+// Close releases the underlying gzip reader and HTTP response body.
+func (it *FeedIterator) Close() error {
+	if it.gz != nil {
+		it.gz.Close()
+	}
+	if it.body == nil {
+		return nil
+	}
+	return it.body.Close()
+}
+
+func parseRangeHeader(h string) (start, end int64) {
+	h = strings.TrimPrefix(h, "bytes=")
+	parts := strings.SplitN(h, "-", 2)
+	if len(parts) != 2 {
+		return 0, feedChunkSize - 1
+	}
+	start, _ = strconv.ParseInt(parts[0], 10, 64)
+	end, _ = strconv.ParseInt(parts[1], 10, 64)
+	return start, end
+}
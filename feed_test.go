@@ -0,0 +1,209 @@
+package ebay_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kemics/ebay"
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipTSV(t *testing.T, rows ...string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	for _, row := range rows {
+		_, err := gz.Write([]byte(row + "\n"))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, gz.Close())
+	return buf.Bytes()
+}
+
+// closeTrackingTransport wraps every response body in a closeTrackingBody so
+// tests can assert the feed package actually closes what it opens.
+type closeTrackingTransport struct {
+	http.RoundTripper
+	closed int32
+}
+
+func (t *closeTrackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	resp.Body = closeTrackingBody{resp.Body, &t.closed}
+	return resp, nil
+}
+
+type closeTrackingBody struct {
+	io.ReadCloser
+	closed *int32
+}
+
+func (b closeTrackingBody) Close() error {
+	atomic.AddInt32(b.closed, 1)
+	return b.ReadCloser.Close()
+}
+
+func TestGetItemFeedParsesRows(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/buy/feed/v1_beta/item", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "bytes=0-104857599", r.Header.Get("Range"))
+		w.Write(gzipTSV(t, "itemId\ttitle\tprice\tcurrency\tconditionId\tavailableQuantity", "item-1\tA widget\t9.99\tUSD\tNEW\t3"))
+	})
+
+	it, err := client.Buy.Feed.GetItemFeed(context.Background(), "12345", time.Now())
+	assert.Nil(t, err)
+	defer it.Close()
+
+	item, err := it.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "item-1", item.ItemID)
+	assert.Equal(t, 3, item.Quantity)
+
+	_, err = it.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestFeedIteratorClosesBodyOnRolloverAndClose(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tracker := &closeTrackingTransport{RoundTripper: http.DefaultTransport}
+	httpClient := &http.Client{Transport: tracker}
+	client, err := ebay.NewCustomClient(httpClient, server.URL+"/")
+	assert.Nil(t, err)
+
+	var requests int32
+	mux.HandleFunc("/buy/feed/v1_beta/item", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			body := gzipTSV(t, "itemId", "item-1")
+			// A Content-Range naming a total well past this chunk tells the
+			// iterator more is coming, so it rolls over instead of treating
+			// this short body as the whole file.
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(body)-1, len(body)*2))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(body)
+			return
+		}
+		// Second chunk: report the feed as exhausted.
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+	})
+
+	it, err := client.Buy.Feed.GetItemFeed(context.Background(), "12345", time.Now())
+	assert.Nil(t, err)
+
+	_, err = it.Next()
+	assert.Nil(t, err)
+	_, err = it.Next() // exhausts chunk 1, triggers rollover, hits the 416
+	assert.Equal(t, io.EOF, err)
+
+	assert.Nil(t, it.Close())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&tracker.closed))
+}
+
+func TestFeedIteratorThreadsOptsIntoFollowUpChunks(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := ebay.NewCustomClient(server.Client(), server.URL+"/")
+	assert.Nil(t, err)
+
+	var secondChunkAuth string
+	var requests int32
+	mux.HandleFunc("/buy/feed/v1_beta/item", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			body := gzipTSV(t, "itemId", "item-1")
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(body)-1, len(body)*2))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(body)
+			return
+		}
+		secondChunkAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+	})
+
+	opt := func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") }
+	it, err := client.Buy.Feed.GetItemFeed(context.Background(), "12345", time.Now(), opt)
+	assert.Nil(t, err)
+	defer it.Close()
+
+	_, err = it.Next()
+	assert.Nil(t, err)
+	_, err = it.Next()
+	assert.Equal(t, io.EOF, err)
+
+	assert.Equal(t, "Bearer token", secondChunkAuth)
+}
+
+// TestFeedIteratorDetectsLastChunkFromContentLength guards against a
+// regression where the iterator needed a wasted follow-up request (expecting
+// a 416) to learn a sub-100MB feed was already complete, instead of reading
+// that off the first response's Content-Length.
+func TestFeedIteratorDetectsLastChunkFromContentLength(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	var requests int32
+	mux.HandleFunc("/buy/feed/v1_beta/item", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write(gzipTSV(t, "itemId", "item-1"))
+	})
+
+	it, err := client.Buy.Feed.GetItemFeed(context.Background(), "12345", time.Now())
+	assert.Nil(t, err)
+	defer it.Close()
+
+	_, err = it.Next()
+	assert.Nil(t, err)
+	_, err = it.Next()
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+// TestFeedIteratorSurfacesRolloverFailure guards against a regression where
+// a genuine transient failure on rollover (here, a persistent 500) was
+// swallowed into a plain io.EOF once retries were exhausted, indistinguishable
+// from the feed having actually ended.
+func TestFeedIteratorSurfacesRolloverFailure(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := ebay.NewCustomClient(server.Client(), server.URL+"/")
+	assert.Nil(t, err)
+
+	var requests int32
+	mux.HandleFunc("/buy/feed/v1_beta/item", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			body := gzipTSV(t, "itemId", "item-1")
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(body)-1, len(body)*2))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(body)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	it, err := client.Buy.Feed.GetItemFeed(context.Background(), "12345", time.Now())
+	assert.Nil(t, err)
+	defer it.Close()
+
+	_, err = it.Next()
+	assert.Nil(t, err)
+	_, err = it.Next()
+	assert.NotNil(t, err)
+	assert.NotEqual(t, io.EOF, err)
+}
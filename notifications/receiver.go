@@ -0,0 +1,317 @@
+package notifications
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/kemics/ebay"
+)
+
+// Notification topics eBay's Platform Notification API delivers to a
+// Receiver's registered handlers.
+//
+// eBay API docs: https://developer.ebay.com/api-docs/commerce/notification/overview.html#topics
+const (
+	TopicItemSold       = "ITEM_SOLD"
+	TopicBidPlaced      = "BID_PLACED"
+	TopicAuctionEnded   = "AUCTION_ENDED"
+	defaultKeyEndpoint  = "https://api.ebay.com/commerce/notification/v1/public_key/"
+	defaultKeyCacheSize = 32
+	signatureHeaderName = "x-ebay-signature"
+	challengeCodeParam  = "challenge_code"
+)
+
+// ItemSoldEvent is delivered on TopicItemSold.
+type ItemSoldEvent struct {
+	ItemID  string `json:"itemId"`
+	OrderID string `json:"orderId"`
+}
+
+// BidPlacedEvent is delivered on TopicBidPlaced.
+type BidPlacedEvent struct {
+	ItemID string     `json:"itemId"`
+	BidID  string     `json:"bidId"`
+	Amount ebay.Money `json:"amount"`
+}
+
+// AuctionEndedEvent is delivered on TopicAuctionEnded.
+type AuctionEndedEvent struct {
+	ItemID       string             `json:"itemId"`
+	WinningBidID string             `json:"winningBidId"`
+	Status       ebay.AuctionStatus `json:"status"`
+}
+
+// envelope is the JSON body of every eBay notification delivery.
+type envelope struct {
+	Metadata struct {
+		Topic string `json:"topic"`
+	} `json:"metadata"`
+	Notification struct {
+		NotificationID string          `json:"notificationId"`
+		Data           json.RawMessage `json:"data"`
+	} `json:"notification"`
+}
+
+// Receiver verifies and dispatches eBay Platform Notification webhook
+// deliveries. It implements http.Handler so it can be mounted directly on a
+// ServeMux.
+type Receiver struct {
+	// KeyEndpoint is the eBay key-management endpoint used to fetch the
+	// public key identified by a delivery's kid. Defaults to eBay's
+	// production endpoint.
+	KeyEndpoint string
+	// HTTPClient is used to fetch public keys. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// VerificationToken and EndpointURL answer eBay's account-deletion
+	// verification challenge handshake (a GET carrying challenge_code).
+	VerificationToken string
+	EndpointURL       string
+
+	keys keyCache
+
+	mu       sync.RWMutex
+	handlers map[string][]func(context.Context, json.RawMessage) error
+}
+
+// on registers a raw handler for topic.
+func (r *Receiver) on(topic string, fn func(context.Context, json.RawMessage) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.handlers == nil {
+		r.handlers = make(map[string][]func(context.Context, json.RawMessage) error)
+	}
+	r.handlers[topic] = append(r.handlers[topic], fn)
+}
+
+// OnItemSold registers fn to run for every TopicItemSold delivery.
+func (r *Receiver) OnItemSold(fn func(ctx context.Context, e *ItemSoldEvent) error) {
+	r.on(TopicItemSold, func(ctx context.Context, raw json.RawMessage) error {
+		var e ItemSoldEvent
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return err
+		}
+		return fn(ctx, &e)
+	})
+}
+
+// OnBidPlaced registers fn to run for every TopicBidPlaced delivery.
+func (r *Receiver) OnBidPlaced(fn func(ctx context.Context, e *BidPlacedEvent) error) {
+	r.on(TopicBidPlaced, func(ctx context.Context, raw json.RawMessage) error {
+		var e BidPlacedEvent
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return err
+		}
+		return fn(ctx, &e)
+	})
+}
+
+// OnAuctionEnded registers fn to run for every TopicAuctionEnded delivery.
+func (r *Receiver) OnAuctionEnded(fn func(ctx context.Context, e *AuctionEndedEvent) error) {
+	r.on(TopicAuctionEnded, func(ctx context.Context, raw json.RawMessage) error {
+		var e AuctionEndedEvent
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return err
+		}
+		return fn(ctx, &e)
+	})
+}
+
+// ServeHTTP verifies the delivery's signature and dispatches it to every
+// handler registered for its topic. eBay's account-deletion verification
+// handshake (a GET with a challenge_code query param) is answered
+// automatically.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodGet {
+		r.serveChallenge(w, req)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.verify(req.Context(), req.Header.Get(signatureHeaderName), body); err != nil {
+		http.Error(w, fmt.Sprintf("signature verification failed: %v", err), http.StatusUnauthorized)
+		return
+	}
+
+	var env envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		http.Error(w, "malformed notification body", http.StatusBadRequest)
+		return
+	}
+
+	r.mu.RLock()
+	handlers := r.handlers[env.Metadata.Topic]
+	r.mu.RUnlock()
+
+	for _, h := range handlers {
+		if err := h(req.Context(), env.Notification.Data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// serveChallenge answers eBay's account-deletion verification handshake: the
+// SHA-256 hash of challenge_code, VerificationToken and EndpointURL,
+// concatenated in that order.
+func (r *Receiver) serveChallenge(w http.ResponseWriter, req *http.Request) {
+	challenge := req.URL.Query().Get(challengeCodeParam)
+	if challenge == "" {
+		http.Error(w, "missing challenge_code", http.StatusBadRequest)
+		return
+	}
+	sum := sha256.Sum256([]byte(challenge + r.VerificationToken + r.EndpointURL))
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		ChallengeResponse string `json:"challengeResponse"`
+	}{ChallengeResponse: hex.EncodeToString(sum[:])})
+}
+
+// signatureHeader is the decoded x-ebay-signature header value.
+type signatureHeader struct {
+	Alg       string `json:"alg"`
+	Signature string `json:"signature"`
+	Kid       string `json:"kid"`
+}
+
+func (r *Receiver) verify(ctx context.Context, header string, body []byte) error {
+	if header == "" {
+		return errors.New("missing " + signatureHeaderName + " header")
+	}
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return fmt.Errorf("decoding signature header: %w", err)
+	}
+	var sig signatureHeader
+	if err := json.Unmarshal(raw, &sig); err != nil {
+		return fmt.Errorf("parsing signature header: %w", err)
+	}
+
+	pub, err := r.keys.get(ctx, r, sig.Kid)
+	if err != nil {
+		return fmt.Errorf("fetching signing key %q: %w", sig.Kid, err)
+	}
+
+	sigBytes, err := base64.StdEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+
+	digest := sha256.Sum256(body)
+	if !ecdsa.VerifyASN1(pub, digest[:], sigBytes) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (r *Receiver) fetchKey(ctx context.Context, kid string) (*ecdsa.PublicKey, error) {
+	endpoint := r.KeyEndpoint
+	if endpoint == "" {
+		endpoint = defaultKeyEndpoint
+	}
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+kid, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("key endpoint returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Key string `json:"key"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(body.Key))
+	if block == nil {
+		return nil, errors.New("invalid public key PEM")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("signing key is not an ECDSA public key")
+	}
+	return ecdsaPub, nil
+}
+
+// keyCache is an in-memory LRU cache of public keys, keyed by kid, so a
+// Receiver doesn't hit eBay's key-management endpoint on every delivery.
+type keyCache struct {
+	mu       sync.Mutex
+	order    []string
+	entries  map[string]*ecdsa.PublicKey
+	capacity int
+}
+
+func (c *keyCache) get(ctx context.Context, r *Receiver, kid string) (*ecdsa.PublicKey, error) {
+	c.mu.Lock()
+	if key, ok := c.entries[kid]; ok {
+		c.touch(kid)
+		c.mu.Unlock()
+		return key, nil
+	}
+	c.mu.Unlock()
+
+	key, err := r.fetchKey(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]*ecdsa.PublicKey)
+		c.capacity = defaultKeyCacheSize
+	}
+	c.entries[kid] = key
+	c.touch(kid)
+	if len(c.order) > c.capacity {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		delete(c.entries, oldest)
+	}
+	return key, nil
+}
+
+// touch moves kid to the back of the eviction order, marking it most
+// recently used. The caller must hold c.mu.
+func (c *keyCache) touch(kid string) {
+	for i, k := range c.order {
+		if k == kid {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	c.order = append(c.order, kid)
+}
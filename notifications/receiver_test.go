@@ -0,0 +1,139 @@
+package notifications_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kemics/ebay/notifications"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSigner signs delivery bodies with a locally generated ECDSA key,
+// standing in for eBay's signing infrastructure and key-management
+// endpoint in tests.
+type testSigner struct {
+	kid     string
+	private *ecdsa.PrivateKey
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	return &testSigner{kid: "test-key-1", private: key}
+}
+
+// keyServer returns an httptest.Server that answers eBay's public-key
+// endpoint for this signer's kid.
+func (s *testSigner) keyServer(t *testing.T) *httptest.Server {
+	der, err := x509.MarshalPKIXPublicKey(&s.private.PublicKey)
+	assert.Nil(t, err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, s.kid) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(struct {
+			Key string `json:"key"`
+		}{Key: string(pemKey)})
+	}))
+}
+
+// sign signs body and returns the value of an x-ebay-signature header.
+func (s *testSigner) sign(t *testing.T, body []byte) string {
+	digest := sha256.Sum256(body)
+	sig, err := ecdsa.SignASN1(rand.Reader, s.private, digest[:])
+	assert.Nil(t, err)
+
+	header, err := json.Marshal(struct {
+		Alg       string `json:"alg"`
+		Signature string `json:"signature"`
+		Kid       string `json:"kid"`
+	}{Alg: "ecdsa", Signature: base64.StdEncoding.EncodeToString(sig), Kid: s.kid})
+	assert.Nil(t, err)
+
+	return base64.StdEncoding.EncodeToString(header)
+}
+
+func TestReceiverDispatchesToRegisteredHandler(t *testing.T) {
+	signer := newTestSigner(t)
+	keyServer := signer.keyServer(t)
+	defer keyServer.Close()
+
+	r := &notifications.Receiver{KeyEndpoint: keyServer.URL + "/"}
+
+	var got notifications.ItemSoldEvent
+	r.OnItemSold(func(ctx context.Context, e *notifications.ItemSoldEvent) error {
+		got = *e
+		return nil
+	})
+
+	body := []byte(`{"metadata":{"topic":"ITEM_SOLD"},"notification":{"notificationId":"n1","data":{"itemId":"item-1","orderId":"order-1"}}}`)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	req.Header.Set("x-ebay-signature", signer.sign(t, body))
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "item-1", got.ItemID)
+	assert.Equal(t, "order-1", got.OrderID)
+}
+
+func TestReceiverRejectsInvalidSignature(t *testing.T) {
+	signer := newTestSigner(t)
+	keyServer := signer.keyServer(t)
+	defer keyServer.Close()
+
+	r := &notifications.Receiver{KeyEndpoint: keyServer.URL + "/"}
+	r.OnItemSold(func(ctx context.Context, e *notifications.ItemSoldEvent) error {
+		t.Fatal("handler should not run for a tampered body")
+		return nil
+	})
+
+	signed := []byte(`{"metadata":{"topic":"ITEM_SOLD"},"notification":{"notificationId":"n1","data":{"itemId":"item-1"}}}`)
+	tampered := []byte(`{"metadata":{"topic":"ITEM_SOLD"},"notification":{"notificationId":"n1","data":{"itemId":"item-2"}}}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(tampered)))
+	req.Header.Set("x-ebay-signature", signer.sign(t, signed))
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestReceiverAnswersDeletionChallenge(t *testing.T) {
+	r := &notifications.Receiver{
+		VerificationToken: "verification-token",
+		EndpointURL:       "https://example.com/ebay/notifications",
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?challenge_code=abc123", nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var out struct {
+		ChallengeResponse string `json:"challengeResponse"`
+	}
+	assert.Nil(t, json.NewDecoder(rec.Body).Decode(&out))
+
+	want := sha256.Sum256([]byte("abc123" + r.VerificationToken + r.EndpointURL))
+	assert.Equal(t, fmt.Sprintf("%x", want), out.ChallengeResponse)
+}
@@ -0,0 +1,148 @@
+// Package notifications wraps eBay's Platform Notification API: managing
+// subscriptions to marketplace events, and receiving the signed webhook
+// deliveries those subscriptions produce.
+//
+// Unlike BuyAPI/SellAPI, SubscriptionService isn't nested on ebay.Client
+// (there's no Client.Notifications): it depends on *ebay.Client for outbound
+// requests, so embedding it on Client the same way would require package
+// ebay to import this package back, an import cycle. Construct one
+// explicitly with NewSubscriptionService(client) instead. Receiver has no
+// such dependency at all - it's a plain http.Handler verifying inbound
+// webhook deliveries - so it doesn't need a Client reference in the first
+// place.
+//
+// eBay API docs: https://developer.ebay.com/api-docs/commerce/notification/overview.html
+package notifications
+
+import (
+	"context"
+
+	"github.com/kemics/ebay"
+)
+
+// SubscriptionService manages notification destinations and subscriptions.
+// Build one with NewSubscriptionService; see the package doc for why it
+// isn't reachable as a field on ebay.Client.
+//
+// eBay API docs: https://developer.ebay.com/api-docs/commerce/notification/resources/destination/methods/createDestination
+type SubscriptionService struct {
+	Client *ebay.Client
+}
+
+// NewSubscriptionService returns a SubscriptionService that issues requests
+// through client.
+func NewSubscriptionService(client *ebay.Client) *SubscriptionService {
+	return &SubscriptionService{Client: client}
+}
+
+// Destination is a subscriber URL eBay can push notifications to.
+type Destination struct {
+	DestinationID string `json:"destinationId,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Status        string `json:"status,omitempty"`
+	Endpoint      struct {
+		EndpointURL string `json:"endpointURL,omitempty"`
+	} `json:"endpoint"`
+}
+
+// CreateDestination registers a subscriber URL with eBay.
+func (s *SubscriptionService) CreateDestination(ctx context.Context, d *Destination, opts ...ebay.Opt) (*Destination, error) {
+	req, err := s.Client.NewRequest("POST", "commerce/notification/v1/destination", d, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var out Destination
+	if err := s.Client.Do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDestination retrieves a previously registered destination.
+func (s *SubscriptionService) GetDestination(ctx context.Context, destinationID string, opts ...ebay.Opt) (*Destination, error) {
+	req, err := s.Client.NewRequest("GET", "commerce/notification/v1/destination/"+destinationID, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var out Destination
+	if err := s.Client.Do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteDestination removes a destination.
+func (s *SubscriptionService) DeleteDestination(ctx context.Context, destinationID string, opts ...ebay.Opt) error {
+	req, err := s.Client.NewRequest("DELETE", "commerce/notification/v1/destination/"+destinationID, nil, opts...)
+	if err != nil {
+		return err
+	}
+	return s.Client.Do(ctx, req, nil)
+}
+
+// Subscription ties a topic to a destination, with the payload format
+// eBay should deliver events in.
+//
+// eBay API docs: https://developer.ebay.com/api-docs/commerce/notification/resources/subscription/methods/createSubscription
+type Subscription struct {
+	SubscriptionID string `json:"subscriptionId,omitempty"`
+	TopicID        string `json:"topicId,omitempty"`
+	Status         string `json:"status,omitempty"`
+	DestinationID  string `json:"destinationId,omitempty"`
+	Payload        struct {
+		Format string `json:"format,omitempty"`
+	} `json:"payload,omitempty"`
+}
+
+// CreateSubscription subscribes a destination to a topic.
+func (s *SubscriptionService) CreateSubscription(ctx context.Context, sub *Subscription, opts ...ebay.Opt) (*Subscription, error) {
+	req, err := s.Client.NewRequest("POST", "commerce/notification/v1/subscription", sub, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var out Subscription
+	if err := s.Client.Do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSubscription retrieves a subscription.
+func (s *SubscriptionService) GetSubscription(ctx context.Context, subscriptionID string, opts ...ebay.Opt) (*Subscription, error) {
+	req, err := s.Client.NewRequest("GET", "commerce/notification/v1/subscription/"+subscriptionID, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var out Subscription
+	if err := s.Client.Do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteSubscription removes a subscription.
+func (s *SubscriptionService) DeleteSubscription(ctx context.Context, subscriptionID string, opts ...ebay.Opt) error {
+	req, err := s.Client.NewRequest("DELETE", "commerce/notification/v1/subscription/"+subscriptionID, nil, opts...)
+	if err != nil {
+		return err
+	}
+	return s.Client.Do(ctx, req, nil)
+}
+
+// EnableSubscription resumes deliveries for a previously disabled subscription.
+func (s *SubscriptionService) EnableSubscription(ctx context.Context, subscriptionID string, opts ...ebay.Opt) error {
+	req, err := s.Client.NewRequest("POST", "commerce/notification/v1/subscription/"+subscriptionID+"/enable", nil, opts...)
+	if err != nil {
+		return err
+	}
+	return s.Client.Do(ctx, req, nil)
+}
+
+// DisableSubscription pauses deliveries for a subscription without deleting it.
+func (s *SubscriptionService) DisableSubscription(ctx context.Context, subscriptionID string, opts ...ebay.Opt) error {
+	req, err := s.Client.NewRequest("POST", "commerce/notification/v1/subscription/"+subscriptionID+"/disable", nil, opts...)
+	if err != nil {
+		return err
+	}
+	return s.Client.Do(ctx, req, nil)
+}
@@ -0,0 +1,141 @@
+package notifications_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kemics/ebay"
+	"github.com/kemics/ebay/notifications"
+	"github.com/stretchr/testify/assert"
+)
+
+// setup stands up an httptest server plus a SubscriptionService wired to hit
+// it, mirroring the ebay package's own setup(t) test helper.
+func setup(t *testing.T) (*notifications.SubscriptionService, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client, err := ebay.NewCustomClient(server.Client(), server.URL+"/")
+	assert.Nil(t, err)
+
+	return notifications.NewSubscriptionService(client), mux, server.Close
+}
+
+func TestCreateDestination(t *testing.T) {
+	svc, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/commerce/notification/v1/destination", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		fmt.Fprint(w, `{"destinationId":"dest-1","name":"my-endpoint","status":"ENABLED"}`)
+	})
+
+	dest, err := svc.CreateDestination(context.Background(), &notifications.Destination{Name: "my-endpoint"})
+	assert.Nil(t, err)
+	assert.Equal(t, "dest-1", dest.DestinationID)
+	assert.Equal(t, "ENABLED", dest.Status)
+}
+
+func TestGetDestination(t *testing.T) {
+	svc, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/commerce/notification/v1/destination/dest-1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{"destinationId":"dest-1","name":"my-endpoint"}`)
+	})
+
+	dest, err := svc.GetDestination(context.Background(), "dest-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "dest-1", dest.DestinationID)
+	assert.Equal(t, "my-endpoint", dest.Name)
+}
+
+func TestDeleteDestination(t *testing.T) {
+	svc, mux, teardown := setup(t)
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc("/commerce/notification/v1/destination/dest-1", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "DELETE", r.Method)
+	})
+
+	assert.Nil(t, svc.DeleteDestination(context.Background(), "dest-1"))
+	assert.True(t, called)
+}
+
+func TestCreateSubscription(t *testing.T) {
+	svc, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/commerce/notification/v1/subscription", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		fmt.Fprint(w, `{"subscriptionId":"sub-1","topicId":"ITEM_SOLD","status":"ENABLED"}`)
+	})
+
+	sub, err := svc.CreateSubscription(context.Background(), &notifications.Subscription{TopicID: "ITEM_SOLD", DestinationID: "dest-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "sub-1", sub.SubscriptionID)
+	assert.Equal(t, "ENABLED", sub.Status)
+}
+
+func TestGetSubscription(t *testing.T) {
+	svc, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/commerce/notification/v1/subscription/sub-1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{"subscriptionId":"sub-1","topicId":"ITEM_SOLD"}`)
+	})
+
+	sub, err := svc.GetSubscription(context.Background(), "sub-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "sub-1", sub.SubscriptionID)
+	assert.Equal(t, "ITEM_SOLD", sub.TopicID)
+}
+
+func TestDeleteSubscription(t *testing.T) {
+	svc, mux, teardown := setup(t)
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc("/commerce/notification/v1/subscription/sub-1", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "DELETE", r.Method)
+	})
+
+	assert.Nil(t, svc.DeleteSubscription(context.Background(), "sub-1"))
+	assert.True(t, called)
+}
+
+func TestEnableSubscription(t *testing.T) {
+	svc, mux, teardown := setup(t)
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc("/commerce/notification/v1/subscription/sub-1/enable", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "POST", r.Method)
+	})
+
+	assert.Nil(t, svc.EnableSubscription(context.Background(), "sub-1"))
+	assert.True(t, called)
+}
+
+func TestDisableSubscription(t *testing.T) {
+	svc, mux, teardown := setup(t)
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc("/commerce/notification/v1/subscription/sub-1/disable", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "POST", r.Method)
+	})
+
+	assert.Nil(t, svc.DisableSubscription(context.Background(), "sub-1"))
+	assert.True(t, called)
+}
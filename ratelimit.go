@@ -0,0 +1,255 @@
+package ebay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+// resourceFamily buckets a request by the top-level API it targets, since
+// eBay enforces separate per-endpoint QPS caps for Browse, Feed, Sell, etc.,
+// on top of the per-application daily call limit.
+type resourceFamily string
+
+const (
+	familyBrowse resourceFamily = "browse"
+	familyFeed   resourceFamily = "feed"
+	familySell   resourceFamily = "sell"
+	familyOther  resourceFamily = "other"
+)
+
+func familyForPath(path string) resourceFamily {
+	switch {
+	case strings.Contains(path, "/buy/browse/"):
+		return familyBrowse
+	case strings.Contains(path, "/buy/feed/"):
+		return familyFeed
+	case strings.Contains(path, "/sell/"), strings.Contains(path, "/ws/api.dll"):
+		return familySell
+	default:
+		return familyOther
+	}
+}
+
+// ClientOption configures a Client at construction time.
+type ClientOption func(*Client)
+
+// WithRateLimit throttles outgoing requests to rps requests per second per
+// resource family (Browse, Feed, Sell, ...), allowing bursts of up to burst
+// requests.
+func WithRateLimit(rps int, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+// WithRetry enables retry-with-backoff on retryable HTTP statuses and eBay
+// error IDs, following policy.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+// RetryPolicy configures how Client.Do retries a request after a retryable
+// failure.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; later retries back off
+	// exponentially from it, with jitter applied.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay.
+	MaxDelay time.Duration
+	// RetryableStatusCodes are HTTP statuses that trigger a retry.
+	RetryableStatusCodes []int
+	// RetryableErrorIDs are eBay errorId values (see Error.ErrorID) that
+	// trigger a retry even when the HTTP status alone wouldn't.
+	RetryableErrorIDs []int
+}
+
+// DefaultRetryPolicy retries 429/500/502/503/504 and eBay's rate-exceeded
+// error (2001) up to 4 attempts with exponential backoff starting at 250ms.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:          4,
+	BaseDelay:            250 * time.Millisecond,
+	MaxDelay:             10 * time.Second,
+	RetryableStatusCodes: []int{http.StatusTooManyRequests, 500, 502, 503, 504},
+	RetryableErrorIDs:    []int{2001},
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << uint(attempt)
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d)/2+1))
+}
+
+// shouldRetry reports whether resp warrants a retry under p, consulting the
+// eBay error body when the status code alone isn't conclusive. It restores
+// resp.Body so a later CheckResponse can still decode it.
+func (p *RetryPolicy) shouldRetry(resp *http.Response) bool {
+	for _, s := range p.RetryableStatusCodes {
+		if resp.StatusCode == s {
+			return true
+		}
+	}
+	if resp.StatusCode < 400 || len(p.RetryableErrorIDs) == 0 {
+		return false
+	}
+	raw, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(raw))
+	if err != nil {
+		return false
+	}
+	var errData ErrorData
+	if err := json.Unmarshal(raw, &errData); err != nil {
+		return false
+	}
+	for _, e := range errData.Errors {
+		for _, code := range p.RetryableErrorIDs {
+			if e.ErrorID == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RateLimitStats is a point-in-time snapshot of rate-limiter activity per
+// resource family, for observability.
+type RateLimitStats struct {
+	Allowed   map[string]int64
+	Throttled map[string]int64
+	Retried   map[string]int64
+}
+
+// RateLimitStats returns a snapshot of the client's rate-limiter activity.
+// It returns nil if the client was built without WithRateLimit.
+func (c *Client) RateLimitStats() *RateLimitStats {
+	if c.limiter == nil {
+		return nil
+	}
+	return c.limiter.snapshot()
+}
+
+type rateLimiter struct {
+	rps   int
+	burst int
+
+	mu        sync.Mutex
+	limiters  map[resourceFamily]*rate.Limiter
+	allowed   map[resourceFamily]int64
+	throttled map[resourceFamily]int64
+	retried   map[resourceFamily]int64
+}
+
+func newRateLimiter(rps, burst int) *rateLimiter {
+	return &rateLimiter{
+		rps:       rps,
+		burst:     burst,
+		limiters:  make(map[resourceFamily]*rate.Limiter),
+		allowed:   make(map[resourceFamily]int64),
+		throttled: make(map[resourceFamily]int64),
+		retried:   make(map[resourceFamily]int64),
+	}
+}
+
+func (rl *rateLimiter) limiterFor(family resourceFamily) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	l, ok := rl.limiters[family]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(rl.rps), rl.burst)
+		rl.limiters[family] = l
+	}
+	return l
+}
+
+// wait blocks until family's token bucket admits the request, or ctx is done.
+func (rl *rateLimiter) wait(ctx context.Context, family resourceFamily) error {
+	l := rl.limiterFor(family)
+	if l.Allow() {
+		rl.count(rl.allowed, family)
+		return nil
+	}
+	rl.count(rl.throttled, family)
+	return l.Wait(ctx)
+}
+
+func (rl *rateLimiter) recordRetry(family resourceFamily) {
+	rl.count(rl.retried, family)
+}
+
+func (rl *rateLimiter) count(m map[resourceFamily]int64, family resourceFamily) {
+	rl.mu.Lock()
+	m[family]++
+	rl.mu.Unlock()
+}
+
+func (rl *rateLimiter) snapshot() *RateLimitStats {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	stats := &RateLimitStats{
+		Allowed:   make(map[string]int64, len(rl.allowed)),
+		Throttled: make(map[string]int64, len(rl.throttled)),
+		Retried:   make(map[string]int64, len(rl.retried)),
+	}
+	for f, n := range rl.allowed {
+		stats.Allowed[string(f)] = n
+	}
+	for f, n := range rl.throttled {
+		stats.Throttled[string(f)] = n
+	}
+	for f, n := range rl.retried {
+		stats.Retried[string(f)] = n
+	}
+	return stats
+}
+
+// rewindBody resets req.Body for a retry, using the GetBody snapshot Go's
+// http.NewRequest sets up automatically for in-memory request bodies.
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Body = body
+	return nil
+}
+
+// parseRetryAfter parses eBay's Retry-After header, expressed in seconds.
+func parseRetryAfter(v string) time.Duration {
+	secs, err := strconv.Atoi(v)
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -0,0 +1,157 @@
+package ebay_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kemics/ebay"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupWithRetry is like setup(t), but builds a client with WithRetry
+// enabled (plus any extra ClientOptions) so tests can exercise Client.Do's
+// backoff behavior.
+func setupWithRetry(t *testing.T, policy ebay.RetryPolicy, opts ...ebay.ClientOption) (*ebay.Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	opts = append([]ebay.ClientOption{ebay.WithRetry(policy)}, opts...)
+	client, err := ebay.NewCustomClient(server.Client(), server.URL+"/", opts...)
+	assert.Nil(t, err)
+
+	return client, mux, server.Close
+}
+
+func TestDoRetriesOnRateLimitThenSucceeds(t *testing.T) {
+	client, mux, teardown := setupWithRetry(t, ebay.RetryPolicy{
+		MaxAttempts:          4,
+		BaseDelay:            time.Millisecond,
+		MaxDelay:             10 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests},
+	})
+	defer teardown()
+
+	var attempts int
+	mux.HandleFunc("/buy/browse/v1/item/get_item_by_legacy_id", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"itemId": "itemId"}`)
+	})
+
+	item, err := client.Buy.Browse.GetItemByLegacyID(context.Background(), "202117468662")
+	assert.Nil(t, err)
+	assert.Equal(t, "itemId", item.ItemID)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestDoGivesUpAfterMaxAttempts(t *testing.T) {
+	policy := ebay.RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            time.Millisecond,
+		MaxDelay:             10 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests},
+	}
+	client, mux, teardown := setupWithRetry(t, policy)
+	defer teardown()
+
+	var attempts int
+	mux.HandleFunc("/buy/browse/v1/item/get_item_by_legacy_id", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := client.Buy.Browse.GetItemByLegacyID(context.Background(), "202117468662")
+	assert.NotNil(t, err)
+	assert.Equal(t, policy.MaxAttempts, attempts)
+}
+
+func TestRateLimitAllowsBurstThenThrottles(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := ebay.NewCustomClient(server.Client(), server.URL+"/", ebay.WithRateLimit(1000, 1))
+	assert.Nil(t, err)
+
+	mux.HandleFunc("/buy/browse/v1/item/get_item_by_legacy_id", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"itemId": "itemId"}`)
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Buy.Browse.GetItemByLegacyID(context.Background(), "202117468662")
+		assert.Nil(t, err)
+	}
+
+	stats := client.RateLimitStats()
+	assert.NotNil(t, stats)
+	assert.Equal(t, int64(1), stats.Allowed["browse"])
+	assert.Equal(t, int64(2), stats.Throttled["browse"])
+}
+
+func TestRateLimitStatsNilWithoutWithRateLimit(t *testing.T) {
+	client, _, teardown := setup(t)
+	defer teardown()
+
+	assert.Nil(t, client.RateLimitStats())
+}
+
+func TestRateLimitTracksRetriesPerFamily(t *testing.T) {
+	client, mux, teardown := setupWithRetry(t, ebay.RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            time.Millisecond,
+		MaxDelay:             10 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests},
+	}, ebay.WithRateLimit(1000, 10))
+	defer teardown()
+
+	var attempts int
+	mux.HandleFunc("/buy/browse/v1/item/get_item_by_legacy_id", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"itemId": "itemId"}`)
+	})
+
+	_, err := client.Buy.Browse.GetItemByLegacyID(context.Background(), "202117468662")
+	assert.Nil(t, err)
+
+	stats := client.RateLimitStats()
+	assert.Equal(t, int64(1), stats.Retried["browse"])
+}
+
+func TestDoHonorsRetryAfter(t *testing.T) {
+	client, mux, teardown := setupWithRetry(t, ebay.RetryPolicy{
+		MaxAttempts:          2,
+		BaseDelay:            time.Millisecond,
+		MaxDelay:             10 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests},
+	})
+	defer teardown()
+
+	var attempts int
+	var first time.Time
+	mux.HandleFunc("/buy/browse/v1/item/get_item_by_legacy_id", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		assert.True(t, time.Since(first) >= time.Second)
+		fmt.Fprint(w, `{"itemId": "itemId"}`)
+	})
+
+	_, err := client.Buy.Browse.GetItemByLegacyID(context.Background(), "202117468662")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+}
@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kemics/ebay"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// TestAuctionBidding is a verbose integration test that exercises a full
+// look up auction -> place proxy bid -> retrieve bid history -> verify
+// winning bid happy path against the sandbox.
+//
+// You have to manually create an auction in the sandbox and retrieve its
+// URL; auctions can't be created using the REST API (yet?).
+func TestAuctionBidding(t *testing.T) {
+	if !integration {
+		t.SkipNow()
+	}
+
+	auctionURL := os.Getenv("SANDOX_AUCTION_URL")
+
+	ctx := context.Background()
+
+	conf := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     ebay.OAuth20SandboxEndpoint.TokenURL,
+		Scopes:       []string{ebay.ScopeRoot},
+	}
+
+	client := ebay.NewSandboxClient(oauth2.NewClient(ctx, ebay.TokenSource(conf.TokenSource(ctx))))
+
+	lit, err := client.Buy.Browse.GetItemByLegacyID(ctx, auctionURL[strings.LastIndex(auctionURL, "/")+1:])
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	offer, err := client.Sell.Trading.PlaceOffer(ctx, lit.ItemID, ebay.Money{Value: "12.50", Currency: "USD"})
+	if err != nil {
+		if ebay.IsError(err, ebay.ErrorAuctionEnded) {
+			t.Skip("sandbox auction has already ended")
+		}
+		t.Fatalf("%+v", err)
+	}
+	t.Logf("placed bid, high bidder: %v", offer.HighBidder)
+	if !offer.HighBidder {
+		t.Fatalf("expected the placed bid to be the winning bid")
+	}
+
+	history, err := client.Sell.Trading.GetBids(ctx, lit.ItemID)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(history.Bids) == 0 {
+		t.Fatalf("expected at least one bid in history, got none")
+	}
+
+	mine, err := client.Sell.Trading.GetMyBidding(ctx)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var winning bool
+	for _, item := range mine.Items {
+		if item.ItemID == lit.ItemID {
+			winning = true
+		}
+	}
+	if !winning {
+		t.Fatalf("expected %q to be in the authenticated user's bidding list", lit.ItemID)
+	}
+}
@@ -0,0 +1,116 @@
+package ebay
+
+import (
+	"context"
+	"net/http"
+	"net/http/httputil"
+	"time"
+)
+
+// RequestEvent describes the outcome of a single HTTP attempt made by
+// Client.Do or Client.DoStream, including retries, so callers can wire
+// Client activity into their own logging or tracing (zap, zerolog,
+// OpenTelemetry, ...) via WithLogger.
+type RequestEvent struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Duration   time.Duration
+	// Attempt is 1 for the first try, 2 for the first retry, and so on.
+	Attempt int
+	// Err is the transport error for this attempt, if any. It is nil for
+	// attempts that received an HTTP response, even an error status.
+	Err error
+}
+
+// WithLogger registers fn to be called with a RequestEvent after every
+// attempt Client.Do/DoStream makes. fn runs synchronously on the calling
+// goroutine and must not block.
+func WithLogger(fn func(RequestEvent)) ClientOption {
+	return func(c *Client) {
+		c.logger = fn
+	}
+}
+
+// WithRequestDeadline bounds the total time a single Client.Do/DoStream call,
+// including retries, may take. It is applied via context.WithTimeout, and
+// only takes effect when the caller's context doesn't already carry a
+// deadline.
+func WithRequestDeadline(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.requestDeadline = d
+	}
+}
+
+// WithBodyCapture includes up to max bytes of the request and response
+// bodies in the debug dump attached to ErrorData. Without it, only headers
+// are dumped, since reading a body to capture it costs an allocation on
+// every request. Capture only happens at all when a logger is configured.
+// max <= 0 disables body capture.
+func WithBodyCapture(max int) ClientOption {
+	return func(c *Client) {
+		c.bodyCaptureMax = max
+	}
+}
+
+// withDeadline applies c's configured request deadline via
+// context.WithTimeout, unless ctx already carries a deadline. The returned
+// cancel func is nil when no deadline was applied, in which case the caller
+// need not call it.
+func (c *Client) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.requestDeadline <= 0 {
+		return ctx, nil
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, nil
+	}
+	return context.WithTimeout(ctx, c.requestDeadline)
+}
+
+// dumpRequest returns a debug dump of req for ErrorData, or "" if no logger
+// is configured. The body is only read, and the dump truncated to
+// c.bodyCaptureMax bytes, when WithBodyCapture was also set.
+func (c *Client) dumpRequest(req *http.Request) string {
+	if c.logger == nil {
+		return ""
+	}
+	dump, _ := httputil.DumpRequest(req, c.bodyCaptureMax > 0)
+	if c.bodyCaptureMax > 0 && len(dump) > c.bodyCaptureMax {
+		dump = dump[:c.bodyCaptureMax]
+	}
+	return string(dump)
+}
+
+// dumpResponse returns a debug dump of resp for ErrorData, or "" if no
+// logger is configured. Like dumpRequest, the body is only read, and the
+// dump truncated to c.bodyCaptureMax bytes, when WithBodyCapture was also
+// set; resp.Body is left intact either way for the caller to still read.
+func (c *Client) dumpResponse(resp *http.Response) string {
+	if c.logger == nil {
+		return ""
+	}
+	dump, _ := httputil.DumpResponse(resp, c.bodyCaptureMax > 0)
+	if c.bodyCaptureMax > 0 && len(dump) > c.bodyCaptureMax {
+		dump = dump[:c.bodyCaptureMax]
+	}
+	return string(dump)
+}
+
+// logRequest reports a RequestEvent for one attempt to c's logger, if any is
+// configured.
+func (c *Client) logRequest(req *http.Request, resp *http.Response, attempt int, d time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+	event := RequestEvent{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Duration: d,
+		Attempt:  attempt + 1,
+		Err:      err,
+	}
+	if resp != nil {
+		event.StatusCode = resp.StatusCode
+	}
+	c.logger(event)
+}
@@ -0,0 +1,132 @@
+package ebay_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kemics/ebay"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoLogsOncePerAttempt(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var mu sync.Mutex
+	var events []ebay.RequestEvent
+	client, err := ebay.NewCustomClient(server.Client(), server.URL+"/",
+		ebay.WithRetry(ebay.RetryPolicy{
+			MaxAttempts:          3,
+			BaseDelay:            time.Millisecond,
+			MaxDelay:             10 * time.Millisecond,
+			RetryableStatusCodes: []int{http.StatusTooManyRequests},
+		}),
+		ebay.WithLogger(func(e ebay.RequestEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, e)
+		}),
+	)
+	assert.Nil(t, err)
+
+	var attempts int
+	mux.HandleFunc("/buy/browse/v1/item/get_item_by_legacy_id", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"itemId": "itemId"}`)
+	})
+
+	_, err = client.Buy.Browse.GetItemByLegacyID(context.Background(), "202117468662")
+	assert.Nil(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, 1, events[0].Attempt)
+	assert.Equal(t, http.StatusTooManyRequests, events[0].StatusCode)
+	assert.Equal(t, 2, events[1].Attempt)
+	assert.Equal(t, http.StatusOK, events[1].StatusCode)
+}
+
+func TestDoHonorsRequestDeadline(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := ebay.NewCustomClient(server.Client(), server.URL+"/",
+		ebay.WithRequestDeadline(10*time.Millisecond),
+	)
+	assert.Nil(t, err)
+
+	mux.HandleFunc("/buy/browse/v1/item/get_item_by_legacy_id", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, `{"itemId": "itemId"}`)
+	})
+
+	_, err = client.Buy.Browse.GetItemByLegacyID(context.Background(), "202117468662")
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, fmt.Sprintf("%+v", err), "ebay.go")
+}
+
+// TestErrorDataIncludesResponseDumpWhenLoggerConfigured guards against a
+// regression where only the request, never the response, was captured into
+// ErrorData's debug dump, even though WithBodyCapture documents both.
+func TestErrorDataIncludesResponseDumpWhenLoggerConfigured(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/buy/browse/v1/item/get_item_by_legacy_id", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors":[{"errorId":12345,"message":"boom"}]}`)
+	})
+
+	client, err := ebay.NewCustomClient(server.Client(), server.URL+"/")
+	assert.Nil(t, err)
+	_, err = client.Buy.Browse.GetItemByLegacyID(context.Background(), "202117468662")
+	assert.NotNil(t, err)
+	assert.NotContains(t, fmt.Sprintf("%+v", err), "HTTP/1.1")
+
+	loggingClient, err := ebay.NewCustomClient(server.Client(), server.URL+"/",
+		ebay.WithLogger(func(ebay.RequestEvent) {}),
+	)
+	assert.Nil(t, err)
+	_, err = loggingClient.Buy.Browse.GetItemByLegacyID(context.Background(), "202117468662")
+	assert.NotNil(t, err)
+	assert.Contains(t, fmt.Sprintf("%+v", err), "HTTP/1.1 400 Bad Request")
+}
+
+func TestDoRequestDeadlineDoesNotOverrideCallerDeadline(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := ebay.NewCustomClient(server.Client(), server.URL+"/",
+		ebay.WithRequestDeadline(10*time.Millisecond),
+	)
+	assert.Nil(t, err)
+
+	mux.HandleFunc("/buy/browse/v1/item/get_item_by_legacy_id", func(w http.ResponseWriter, r *http.Request) {
+		// Slower than the client's 10ms deadline, well within the caller's.
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprint(w, `{"itemId": "itemId"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	item, err := client.Buy.Browse.GetItemByLegacyID(ctx, "202117468662")
+	assert.Nil(t, err)
+	assert.Equal(t, "itemId", item.ItemID)
+}
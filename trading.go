@@ -0,0 +1,336 @@
+package ebay
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// eBay Trading API endpoints.
+//
+// Unlike the REST Buy/Sell APIs, the Trading API is hosted on a single legacy
+// endpoint that speaks XML, regardless of Client.baseURL.
+const (
+	tradingURL        = "https://api.ebay.com/ws/api.dll"
+	tradingSandboxURL = "https://api.sandbox.ebay.com/ws/api.dll"
+
+	tradingXMLNS              = "urn:ebay:apis:eBLBaseComponents"
+	tradingCompatibilityLevel = "1199"
+)
+
+// SellAPI regroups the eBay Sell APIs.
+//
+// eBay API docs: https://developer.ebay.com/api-docs/sell/static/sell-landing.html
+type SellAPI struct {
+	Trading *TradingService
+}
+
+// TradingService handles communication with the auction-bidding related
+// methods of eBay's Trading API.
+//
+// eBay API docs: https://developer.ebay.com/devzone/xml/docs/Reference/eBay/index.html
+type TradingService service
+
+// Money represents an amount of money in a given currency, as used throughout
+// eBay's XML and REST APIs.
+type Money struct {
+	Value    string `xml:",chardata" json:"value,omitempty"`
+	Currency string `xml:"currencyID,attr" json:"currency,omitempty"`
+}
+
+// AuctionStatus describes the current state of an auction listing.
+type AuctionStatus string
+
+// Known auction statuses.
+const (
+	AuctionStatusActive AuctionStatus = "Active"
+	AuctionStatusEnded  AuctionStatus = "Ended"
+	AuctionStatusSold   AuctionStatus = "Sold"
+)
+
+// Trading API error codes relevant to auction bidding.
+//
+// eBay API docs: https://developer.ebay.com/devzone/xml/docs/Reference/eBay/Errors/errormessages.htm
+const (
+	ErrorBidTooLow    = 291 // "The bid must be higher than the current bid amount."
+	ErrorAuctionEnded = 293 // "This auction (item) has ended."
+)
+
+// Bid describes a single bid placed on an auction item.
+type Bid struct {
+	BidID   string `xml:"BidID"`
+	Bidder  string `xml:"Bidder>UserID"`
+	Amount  Money  `xml:"Amount"`
+	MaxBid  bool   `xml:"MaxBid"`
+	TimeBid string `xml:"TimeBid"`
+}
+
+// BidHistory is the list of bids placed on an item, most recent first.
+type BidHistory struct {
+	ItemID       string `xml:"Item>ItemID"`
+	CurrentPrice Money  `xml:"Item>SellingStatus>CurrentPrice"`
+	Bids         []Bid  `xml:"Bidding>BidArray>Bid"`
+}
+
+// Offer is the result of placing a proxy bid on an auction item. HighBidder
+// only reports whether this bid currently leads the auction, not whether the
+// auction itself has ended; call GetBids or GetMyBidding for that.
+type Offer struct {
+	ItemID       string `xml:"Item>ItemID"`
+	HighBidder   bool   `xml:"HighBidder"`
+	MinimumToBid Money  `xml:"MinimumToBid"`
+}
+
+// MyBidding lists the auctions the authenticated user is currently bidding on.
+type MyBidding struct {
+	Items []BidHistory `xml:"BiddingSummary>ItemArray>Item"`
+}
+
+// tradingEnvelope is embedded in every Trading API response body and carries
+// the Ack/Errors fields common to all calls.
+type tradingEnvelope struct {
+	Ack    string         `xml:"Ack"`
+	Errors []tradingError `xml:"Errors"`
+}
+
+type tradingError struct {
+	ErrorCode    int    `xml:"ErrorCode"`
+	ShortMessage string `xml:"ShortMessage"`
+	LongMessage  string `xml:"LongMessage"`
+	SeverityCode string `xml:"SeverityCode"`
+}
+
+// tradingURLFor returns the Trading API endpoint matching c.baseURL, so
+// TradingService hits the sandbox whenever the rest of the client does. A
+// client built with NewCustomClient (tests, local mocks) gets its Trading
+// calls routed at the custom base URL too, rather than always hitting the
+// real eBay endpoints.
+func (c *Client) tradingURLFor() string {
+	switch c.baseURL.String() {
+	case BaseURL:
+		return tradingURL
+	case SandboxBaseURL:
+		return tradingSandboxURL
+	default:
+		u, _ := c.baseURL.Parse("ws/api.dll")
+		return u.String()
+	}
+}
+
+// doTrading posts an XML-encoded Trading API request identified by callName
+// and decodes the XML response into v, converting any Ack=Failure into an
+// *ErrorData so callers can keep using IsError.
+func (c *Client) doTrading(ctx context.Context, callName string, body, v interface{}, opts ...Opt) error {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	if err := enc.Encode(body); err != nil {
+		return errors.WithStack(err)
+	}
+
+	req, err := http.NewRequest("POST", c.tradingURLFor(), &buf)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "text/xml")
+	req.Header.Set("X-EBAY-API-CALL-NAME", callName)
+	req.Header.Set("X-EBAY-API-COMPATIBILITY-LEVEL", tradingCompatibilityLevel)
+	req.Header.Set("X-EBAY-API-SITEID", "0")
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	ctx, cancel := c.withDeadline(ctx)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body) //nolint:errcheck
+		resp.Body.Close()
+	}()
+
+	dec := xml.NewDecoder(resp.Body)
+	if err := dec.Decode(v); err != nil {
+		return errors.WithStack(err)
+	}
+
+	env, ok := v.(interface{ envelope() tradingEnvelope })
+	if !ok {
+		return nil
+	}
+	if e := env.envelope(); e.Ack == "Failure" || e.Ack == "PartialFailure" {
+		errData := &ErrorData{response: resp}
+		for _, te := range e.Errors {
+			errData.Errors = append(errData.Errors, Error{
+				ErrorID:     te.ErrorCode,
+				Message:     te.ShortMessage,
+				LongMessage: te.LongMessage,
+				Category:    te.SeverityCode,
+			})
+		}
+		return errData
+	}
+	return nil
+}
+
+type placeOfferRequest struct {
+	XMLName xml.Name `xml:"PlaceOfferRequest"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	ItemID  string   `xml:"ItemID"`
+	Offer   struct {
+		Action string `xml:"Action"`
+		MaxBid Money  `xml:"MaxBid"`
+	} `xml:"Offer"`
+}
+
+type placeOfferResponse struct {
+	XMLName xml.Name `xml:"PlaceOfferResponse"`
+	tradingEnvelope
+	Item struct {
+		ItemID string `xml:"ItemID"`
+	} `xml:"Item"`
+	HighBidder   bool  `xml:"HighBidder"`
+	MinimumToBid Money `xml:"MinimumToBid"`
+}
+
+func (r *placeOfferResponse) envelope() tradingEnvelope { return r.tradingEnvelope }
+
+// PlaceOffer places a proxy (maximum) bid on an auction item.
+//
+// eBay API docs: https://developer.ebay.com/devzone/xml/docs/Reference/eBay/PlaceOffer.html
+func (s *TradingService) PlaceOffer(ctx context.Context, itemID string, maxBid Money, opts ...Opt) (*Offer, error) {
+	body := placeOfferRequest{Xmlns: tradingXMLNS, ItemID: itemID}
+	body.Offer.Action = "Bid"
+	body.Offer.MaxBid = maxBid
+
+	var resp placeOfferResponse
+	if err := s.client.doTrading(ctx, "PlaceOffer", body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &Offer{
+		ItemID:       resp.Item.ItemID,
+		HighBidder:   resp.HighBidder,
+		MinimumToBid: resp.MinimumToBid,
+	}, nil
+}
+
+type getBidsRequest struct {
+	XMLName xml.Name `xml:"GetItemRequest"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	ItemID  string   `xml:"ItemID"`
+}
+
+type getBidsResponse struct {
+	XMLName xml.Name `xml:"GetItemResponse"`
+	tradingEnvelope
+	Item struct {
+		ItemID        string `xml:"ItemID"`
+		SellingStatus struct {
+			CurrentPrice Money `xml:"CurrentPrice"`
+		} `xml:"SellingStatus"`
+	} `xml:"Item"`
+	Bidding struct {
+		BidArray struct {
+			Bid []Bid `xml:"Bid"`
+		} `xml:"BidArray"`
+	} `xml:"Bidding"`
+}
+
+func (r *getBidsResponse) envelope() tradingEnvelope { return r.tradingEnvelope }
+
+// GetBids retrieves the full bid history for an auction item.
+//
+// eBay API docs: https://developer.ebay.com/devzone/xml/docs/Reference/eBay/GetItem.html
+func (s *TradingService) GetBids(ctx context.Context, itemID string, opts ...Opt) (*BidHistory, error) {
+	body := getBidsRequest{Xmlns: tradingXMLNS, ItemID: itemID}
+
+	var resp getBidsResponse
+	if err := s.client.doTrading(ctx, "GetItem", body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &BidHistory{
+		ItemID:       resp.Item.ItemID,
+		CurrentPrice: resp.Item.SellingStatus.CurrentPrice,
+		Bids:         resp.Bidding.BidArray.Bid,
+	}, nil
+}
+
+type getMyBiddingRequest struct {
+	XMLName    xml.Name `xml:"GetMyeBayBuyingRequest"`
+	Xmlns      string   `xml:"xmlns,attr"`
+	ActiveList struct {
+		Include bool `xml:"Include"`
+	} `xml:"BidList"`
+}
+
+type getMyBiddingResponse struct {
+	XMLName xml.Name `xml:"GetMyeBayBuyingResponse"`
+	tradingEnvelope
+	BiddingSummary struct {
+		ItemArray struct {
+			Item []struct {
+				ItemID        string `xml:"ItemID"`
+				SellingStatus struct {
+					CurrentPrice Money `xml:"CurrentPrice"`
+				} `xml:"SellingStatus"`
+			} `xml:"Item"`
+		} `xml:"ItemArray"`
+	} `xml:"BiddingSummary"`
+}
+
+func (r *getMyBiddingResponse) envelope() tradingEnvelope { return r.tradingEnvelope }
+
+// GetMyBidding retrieves the auctions the authenticated user is currently bidding on.
+//
+// eBay API docs: https://developer.ebay.com/devzone/xml/docs/Reference/eBay/GetMyeBayBuying.html
+func (s *TradingService) GetMyBidding(ctx context.Context, opts ...Opt) (*MyBidding, error) {
+	body := getMyBiddingRequest{Xmlns: tradingXMLNS}
+	body.ActiveList.Include = true
+
+	var resp getMyBiddingResponse
+	if err := s.client.doTrading(ctx, "GetMyeBayBuying", body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	mine := &MyBidding{}
+	for _, it := range resp.BiddingSummary.ItemArray.Item {
+		mine.Items = append(mine.Items, BidHistory{
+			ItemID:       it.ItemID,
+			CurrentPrice: it.SellingStatus.CurrentPrice,
+		})
+	}
+	return mine, nil
+}
+
+type cancelBidResponse struct {
+	XMLName xml.Name `xml:"RetractBidResponse"`
+	tradingEnvelope
+}
+
+func (r *cancelBidResponse) envelope() tradingEnvelope { return r.tradingEnvelope }
+
+// CancelBid retracts a bid previously placed with PlaceOffer.
+//
+// eBay only allows retracting a bid under narrow circumstances (e.g. a
+// typo'd amount); eBay API docs:
+// https://developer.ebay.com/devzone/xml/docs/Reference/eBay/RetractBid.html
+func (s *TradingService) CancelBid(ctx context.Context, bidID string, opts ...Opt) error {
+	type retractBidRequest struct {
+		XMLName       xml.Name `xml:"RetractBidRequest"`
+		Xmlns         string   `xml:"xmlns,attr"`
+		BidID         string   `xml:"BidID"`
+		RetractReason string   `xml:"RetractReason"`
+	}
+	body := retractBidRequest{Xmlns: tradingXMLNS, BidID: bidID, RetractReason: "CancelBid"}
+
+	var resp cancelBidResponse
+	return s.client.doTrading(ctx, "RetractBid", body, &resp, opts...)
+}
@@ -0,0 +1,117 @@
+package ebay_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kemics/ebay"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceOffer(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/ws/api.dll", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PlaceOffer", r.Header.Get("X-EBAY-API-CALL-NAME"))
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<PlaceOfferResponse xmlns="urn:ebay:apis:eBLBaseComponents">
+	<Ack>Success</Ack>
+	<Item><ItemID>202117468662</ItemID></Item>
+	<HighBidder>true</HighBidder>
+	<MinimumToBid currencyID="USD">12.50</MinimumToBid>
+</PlaceOfferResponse>`)
+	})
+
+	offer, err := client.Sell.Trading.PlaceOffer(context.Background(), "202117468662", ebay.Money{Value: "12.00", Currency: "USD"})
+	assert.Nil(t, err)
+	assert.Equal(t, "202117468662", offer.ItemID)
+	assert.True(t, offer.HighBidder)
+	assert.Equal(t, "12.50", offer.MinimumToBid.Value)
+}
+
+func TestPlaceOfferTranslatesAckFailure(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/ws/api.dll", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<PlaceOfferResponse xmlns="urn:ebay:apis:eBLBaseComponents">
+	<Ack>Failure</Ack>
+	<Errors>
+		<ErrorCode>293</ErrorCode>
+		<ShortMessage>This auction (item) has ended.</ShortMessage>
+		<SeverityCode>Error</SeverityCode>
+	</Errors>
+</PlaceOfferResponse>`)
+	})
+
+	_, err := client.Sell.Trading.PlaceOffer(context.Background(), "202117468662", ebay.Money{Value: "12.00", Currency: "USD"})
+	assert.NotNil(t, err)
+	assert.True(t, ebay.IsError(err, ebay.ErrorAuctionEnded))
+}
+
+func TestGetBids(t *testing.T) {
+	client, mux, teardown := setup(t)
+	defer teardown()
+
+	mux.HandleFunc("/ws/api.dll", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GetItem", r.Header.Get("X-EBAY-API-CALL-NAME"))
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<GetItemResponse xmlns="urn:ebay:apis:eBLBaseComponents">
+	<Ack>Success</Ack>
+	<Item>
+		<ItemID>202117468662</ItemID>
+		<SellingStatus><CurrentPrice currencyID="USD">12.50</CurrentPrice></SellingStatus>
+	</Item>
+	<Bidding><BidArray><Bid>
+		<BidID>1</BidID>
+		<Bidder><UserID>alice</UserID></Bidder>
+		<Amount currencyID="USD">12.50</Amount>
+	</Bid></BidArray></Bidding>
+</GetItemResponse>`)
+	})
+
+	history, err := client.Sell.Trading.GetBids(context.Background(), "202117468662")
+	assert.Nil(t, err)
+	assert.Equal(t, "202117468662", history.ItemID)
+	assert.Equal(t, "12.50", history.CurrentPrice.Value)
+	assert.Equal(t, "alice", history.Bids[0].Bidder)
+}
+
+// TestTradingGoesThroughSend guards against Sell.Trading bypassing
+// Client.send: it must be rate-limited, retried, and reported to WithLogger
+// exactly like every other service, since familyForPath buckets
+// /ws/api.dll into familySell for that reason.
+func TestTradingGoesThroughSend(t *testing.T) {
+	var events []ebay.RequestEvent
+	client, mux, teardown := setupWithRetry(t, ebay.RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            time.Millisecond,
+		MaxDelay:             10 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	}, ebay.WithLogger(func(e ebay.RequestEvent) { events = append(events, e) }))
+	defer teardown()
+
+	var attempts int
+	mux.HandleFunc("/ws/api.dll", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<GetItemResponse xmlns="urn:ebay:apis:eBLBaseComponents">
+	<Ack>Success</Ack>
+	<Item><ItemID>202117468662</ItemID></Item>
+</GetItemResponse>`)
+	})
+
+	_, err := client.Sell.Trading.GetBids(context.Background(), "202117468662")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 2, len(events))
+}